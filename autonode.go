@@ -0,0 +1,56 @@
+package snowflake
+
+import (
+	"errors"
+	"hash/fnv"
+	"net"
+	"os"
+)
+
+// NewSnowFlakeAuto creates a SnowFlake generator the same way as NewSnowFlake,
+// except that when cfg.AutoNodeID is set it derives cfg.NodeID automatically
+// instead of requiring an explicit value. The node ID is computed by hashing
+// the host's non-loopback MAC addresses, falling back to its hostname, so
+// that container/k8s deployments with stable per-pod MACs don't need a hand
+// assigned NodeID.
+func NewSnowFlakeAuto(cfg Config) (*SnowFlake, error) {
+	if cfg.AutoNodeID {
+		nodeID, err := deriveNodeID(cfg.NodeBits)
+		if err != nil {
+			return nil, err
+		}
+		cfg.NodeID = nodeID
+	}
+
+	return NewSnowFlake(cfg)
+}
+
+// deriveNodeID derives a stable node ID from the machine's MAC addresses,
+// falling back to its hostname, reduced modulo the number of node IDs
+// addressable with nodeBits.
+func deriveNodeID(nodeBits int) (int64, error) {
+	maxNodeID := int64((1 << nodeBits) - 1)
+
+	h := fnv.New64a()
+	hashed := false
+
+	if ifaces, err := net.Interfaces(); err == nil {
+		for _, iface := range ifaces {
+			if iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) == 0 {
+				continue
+			}
+			h.Write(iface.HardwareAddr)
+			hashed = true
+		}
+	}
+
+	if !hashed {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			return 0, errors.New("snowflake: AutoNodeID: no MAC address or hostname available to derive a node ID")
+		}
+		h.Write([]byte(hostname))
+	}
+
+	return int64(h.Sum64() % uint64(maxNodeID+1)), nil
+}
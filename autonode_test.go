@@ -0,0 +1,44 @@
+package snowflake
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSnowFlakeAutoDerivesNodeID(t *testing.T) {
+	cfg := Config{
+		Epoch:        time.Now().Add(-time.Hour).UnixMilli(),
+		NodeBits:     10,
+		SequenceBits: 12,
+		AutoNodeID:   true,
+	}
+
+	ss, err := NewSnowFlakeAuto(cfg)
+	if err != nil {
+		t.Fatalf("NewSnowFlakeAuto failed: %v", err)
+	}
+
+	if err := ss.ValidateNodeID(ss.nodeID); err != nil {
+		t.Errorf("derived nodeID %d is out of range: %v", ss.nodeID, err)
+	}
+
+	id, err := ss.GenerateID()
+	if err != nil {
+		t.Fatalf("GenerateID failed: %v", err)
+	}
+	if id <= 0 {
+		t.Errorf("Generated ID should be greater than 0, got %d", id)
+	}
+}
+
+func TestDeriveNodeIDWithinRange(t *testing.T) {
+	nodeID, err := deriveNodeID(10)
+	if err != nil {
+		t.Fatalf("deriveNodeID failed: %v", err)
+	}
+
+	maxNodeID := int64((1 << 10) - 1)
+	if nodeID < 0 || nodeID > maxNodeID {
+		t.Errorf("deriveNodeID returned %d, expected between 0 and %d", nodeID, maxNodeID)
+	}
+}
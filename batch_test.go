@@ -0,0 +1,107 @@
+package snowflake
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerateIDs(t *testing.T) {
+	cfg := Config{
+		Epoch:        time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:       1,
+		NodeBits:     10,
+		SequenceBits: 12,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	n := 10000
+	ids, err := ss.GenerateIDs(n)
+	if err != nil {
+		t.Fatalf("GenerateIDs failed: %v", err)
+	}
+
+	if len(ids) != n {
+		t.Fatalf("Expected %d IDs, got %d", n, len(ids))
+	}
+
+	seen := make(map[int64]struct{}, n)
+	for _, id := range ids {
+		if _, dup := seen[id]; dup {
+			t.Fatalf("GenerateIDs produced a duplicate ID: %d", id)
+		}
+		seen[id] = struct{}{}
+	}
+}
+
+func TestGenerateCustomIDs(t *testing.T) {
+	cfg := Config{
+		Epoch:        time.Now().UnixMilli(),
+		NodeID:       1,
+		NodeBits:     10,
+		SequenceBits: 12,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	length := 16
+	customIDs, err := ss.GenerateCustomIDs(5, length)
+	if err != nil {
+		t.Fatalf("GenerateCustomIDs failed: %v", err)
+	}
+
+	if len(customIDs) != 5 {
+		t.Fatalf("Expected 5 custom IDs, got %d", len(customIDs))
+	}
+
+	for _, customID := range customIDs {
+		if len(customID) != length {
+			t.Errorf("Expected custom ID length %d, got %d", length, len(customID))
+		}
+	}
+}
+
+func BenchmarkGenerateIDLoop(b *testing.B) {
+	ss, err := NewSnowFlake(Config{
+		Epoch:        time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:       1,
+		NodeBits:     10,
+		SequenceBits: 12,
+	})
+	if err != nil {
+		b.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ss.GenerateID(); err != nil {
+			b.Fatalf("GenerateID failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkGenerateIDs(b *testing.B) {
+	ss, err := NewSnowFlake(Config{
+		Epoch:        time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:       1,
+		NodeBits:     10,
+		SequenceBits: 12,
+	})
+	if err != nil {
+		b.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	const batchSize = 100
+	b.ResetTimer()
+	for i := 0; i < b.N; i += batchSize {
+		if _, err := ss.GenerateIDs(batchSize); err != nil {
+			b.Fatalf("GenerateIDs failed: %v", err)
+		}
+	}
+}
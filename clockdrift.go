@@ -0,0 +1,37 @@
+package snowflake
+
+// ClockDriftPolicy controls how a SnowFlake generator reacts when the wall
+// clock is found to be behind the last timestamp it issued an ID for.
+type ClockDriftPolicy int
+
+const (
+	// PolicyError refuses to generate an ID and returns an error. This is
+	// the default and matches the generator's original behavior.
+	PolicyError ClockDriftPolicy = iota
+	// PolicyWait blocks for up to Config.MaxClockDriftWait for the clock to
+	// catch up, then resumes generating IDs normally.
+	PolicyWait
+	// PolicyExtendedSequence tolerates sequence overflow within a single
+	// time unit by advancing the internal timestamp one tick ahead of the
+	// wall clock instead of blocking, bounded by Config.MaxFutureDrift.
+	PolicyExtendedSequence
+)
+
+// Stats reports cumulative clock-drift compensation performed by a generator.
+type Stats struct {
+	// DriftWaits counts how many times PolicyWait blocked for the clock to catch up.
+	DriftWaits int64
+	// DriftBorrows counts how many times PolicyExtendedSequence borrowed a future tick.
+	DriftBorrows int64
+}
+
+// Stats returns the generator's cumulative clock-drift compensation counters.
+func (ss *SnowFlake) Stats() Stats {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	return Stats{
+		DriftWaits:   ss.driftWaits,
+		DriftBorrows: ss.driftBorrows,
+	}
+}
@@ -0,0 +1,79 @@
+package snowflake
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerateIDClockBackwardDefaultPolicyErrors(t *testing.T) {
+	cfg := Config{
+		Epoch:        time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:       1,
+		NodeBits:     10,
+		SequenceBits: 12,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	ss.lastTimestamp = currentTime(ss.precision) + 1000
+	if _, err := ss.GenerateID(); err == nil {
+		t.Error("GenerateID should fail when the clock is behind lastTimestamp under PolicyError")
+	}
+}
+
+func TestGenerateIDClockBackwardPolicyWait(t *testing.T) {
+	cfg := Config{
+		Epoch:             time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:            1,
+		NodeBits:          10,
+		SequenceBits:      12,
+		ClockDriftPolicy:  PolicyWait,
+		MaxClockDriftWait: 200 * time.Millisecond,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	ss.lastTimestamp = currentTime(ss.precision) + 20
+	if _, err := ss.GenerateID(); err != nil {
+		t.Fatalf("GenerateID under PolicyWait should recover once the clock catches up: %v", err)
+	}
+
+	if stats := ss.Stats(); stats.DriftWaits != 1 {
+		t.Errorf("Expected 1 DriftWait, got %d", stats.DriftWaits)
+	}
+}
+
+func TestGenerateIDExtendedSequenceBorrowsFutureTick(t *testing.T) {
+	cfg := Config{
+		Epoch:            time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:           1,
+		NodeBits:         10,
+		SequenceBits:     2, // small sequence space so it overflows quickly
+		ClockDriftPolicy: PolicyExtendedSequence,
+		MaxFutureDrift:   10,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	n := int(ss.maxSequence) + 2
+	ids, err := ss.GenerateIDs(n)
+	if err != nil {
+		t.Fatalf("GenerateIDs failed: %v", err)
+	}
+	if len(ids) != n {
+		t.Fatalf("Expected %d IDs, got %d", n, len(ids))
+	}
+
+	if stats := ss.Stats(); stats.DriftBorrows == 0 {
+		t.Error("Expected at least one DriftBorrow when the sequence overflowed within a single tick")
+	}
+}
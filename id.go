@@ -0,0 +1,257 @@
+package snowflake
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ID is a generated Snowflake ID with convenience encoding, parsing, and
+// serialization helpers attached. It is a plain int64 underneath, so it can
+// always be converted back with int64(id).
+//
+// ID intentionally has no Time/Node/Sequence methods of its own: decoding an
+// ID requires the bit widths, epoch, and precision of the SnowFlake that
+// generated it, which isn't something a bare int64 can know on its own. Use
+// the matching method on the originating *SnowFlake instead (Time, Node,
+// Sequence, DataCenter, Worker).
+type ID int64
+
+// String returns the base10 representation of the ID.
+func (id ID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// Bytes returns the base10 representation of the ID as a byte slice.
+func (id ID) Bytes() []byte {
+	return []byte(id.String())
+}
+
+// Base2 returns the base2 (binary) representation of the ID.
+func (id ID) Base2() string {
+	return strconv.FormatInt(int64(id), 2)
+}
+
+// ParseBase2 parses a base2 (binary) string into an ID.
+func ParseBase2(s string) (ID, error) {
+	n, err := strconv.ParseInt(s, 2, 64)
+	return ID(n), err
+}
+
+// Base32 returns a base32 encoding of the ID using the z-base-32 alphabet.
+// The encoded strings do not sort in the same order as the underlying integers.
+func (id ID) Base32() string {
+	if id == 0 {
+		return string(base32Charset[0])
+	}
+
+	n := uint64(id)
+	b := make([]byte, 0, 13)
+	for n >= 32 {
+		b = append(b, base32Charset[n%32])
+		n /= 32
+	}
+	b = append(b, base32Charset[n])
+
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+
+	return string(b)
+}
+
+// ParseBase32 parses a Base32-encoded string, as returned by ID.Base32, into an ID.
+func ParseBase32(s string) (ID, error) {
+	var n int64
+	for _, c := range []byte(s) {
+		idx := base32DecodeMap[c]
+		if idx == 0xFF {
+			return 0, fmt.Errorf("snowflake: invalid base32 character %q", c)
+		}
+		n = n*32 + int64(idx)
+	}
+	return ID(n), nil
+}
+
+// Base58 returns a base58 encoding of the ID using the Flickr alphabet.
+func (id ID) Base58() string {
+	if id == 0 {
+		return string(base58Charset[0])
+	}
+
+	n := uint64(id)
+	b := make([]byte, 0, 11)
+	for n >= 58 {
+		b = append(b, base58Charset[n%58])
+		n /= 58
+	}
+	b = append(b, base58Charset[n])
+
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+
+	return string(b)
+}
+
+// ParseBase58 parses a Base58-encoded string, as returned by ID.Base58, into an ID.
+func ParseBase58(s string) (ID, error) {
+	var n int64
+	for _, c := range []byte(s) {
+		idx := base58DecodeMap[c]
+		if idx == 0xFF {
+			return 0, fmt.Errorf("snowflake: invalid base58 character %q", c)
+		}
+		n = n*58 + int64(idx)
+	}
+	return ID(n), nil
+}
+
+// Base62 returns a base62 encoding of the ID.
+func (id ID) Base62() string {
+	return encodeToBase62(int64(id))
+}
+
+// ParseBase62 parses a Base62-encoded string, as returned by ID.Base62, into an ID.
+func ParseBase62(s string) (ID, error) {
+	var n int64
+	for _, c := range []byte(s) {
+		idx := base62DecodeMap[c]
+		if idx == 0xFF {
+			return 0, fmt.Errorf("snowflake: invalid base62 character %q", c)
+		}
+		n = n*62 + int64(idx)
+	}
+	return ID(n), nil
+}
+
+// Base64 returns the standard base64 encoding of the ID's base10 string.
+func (id ID) Base64() string {
+	return base64.StdEncoding.EncodeToString(id.Bytes())
+}
+
+// ParseBase64 parses a string produced by ID.Base64 into an ID.
+func ParseBase64(s string) (ID, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return 0, err
+	}
+	return ParseID(string(b))
+}
+
+// ParseID parses a base10 string, as returned by ID.String, into an ID.
+func ParseID(s string) (ID, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	return ID(n), err
+}
+
+// base32Charset, base58Charset, and their decode maps mirror the alphabets
+// used by popular Snowflake ID libraries: base32 is the z-base-32
+// alphabet avoiding visually similar characters, and base58 is the Flickr
+// alphabet (lowercase before uppercase) avoiding 0/O/I/l.
+const (
+	base32Charset = "ybndrfg8ejkmcpqxot1uwisza345h769"
+	base58Charset = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+var (
+	base32DecodeMap [256]byte
+	base58DecodeMap [256]byte
+	base62DecodeMap [256]byte
+)
+
+func init() {
+	for i := range base32DecodeMap {
+		base32DecodeMap[i] = 0xFF
+	}
+	for i := 0; i < len(base32Charset); i++ {
+		base32DecodeMap[base32Charset[i]] = byte(i)
+	}
+
+	for i := range base58DecodeMap {
+		base58DecodeMap[i] = 0xFF
+	}
+	for i := 0; i < len(base58Charset); i++ {
+		base58DecodeMap[base58Charset[i]] = byte(i)
+	}
+
+	for i := range base62DecodeMap {
+		base62DecodeMap[i] = 0xFF
+	}
+	for i := 0; i < len(base62Charset); i++ {
+		base62DecodeMap[base62Charset[i]] = byte(i)
+	}
+}
+
+// Scan implements sql.Scanner so an ID can be read directly from a database column.
+func (id *ID) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case int64:
+		*id = ID(v)
+	case []byte:
+		parsed, err := ParseID(string(v))
+		if err != nil {
+			return err
+		}
+		*id = parsed
+	case string:
+		parsed, err := ParseID(v)
+		if err != nil {
+			return err
+		}
+		*id = parsed
+	default:
+		return fmt.Errorf("snowflake: unable to scan type %T into ID", value)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer so an ID can be written directly to a database column.
+func (id ID) Value() (driver.Value, error) {
+	return int64(id), nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the ID as a quoted base10
+// string so it survives round-tripping through JavaScript's 53-bit number limit.
+func (id ID) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, len(id.String())+2)
+	buf = append(buf, '"')
+	buf = append(buf, id.String()...)
+	buf = append(buf, '"')
+	return buf, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting both a quoted string
+// and a bare JSON number for convenience.
+func (id *ID) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return errors.New("snowflake: ID.UnmarshalJSON: " + err.Error())
+	}
+	*id = ID(n)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ID) MarshalText() ([]byte, error) {
+	return id.Bytes(), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ID) UnmarshalText(b []byte) error {
+	parsed, err := ParseID(string(b))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
@@ -0,0 +1,130 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGenerateAndDecompose(t *testing.T) {
+	cfg := Config{
+		Epoch:        time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:       5,
+		NodeBits:     10,
+		SequenceBits: 12,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	id, err := ss.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if ss.Node(id) != cfg.NodeID {
+		t.Errorf("Expected node %d, got %d", cfg.NodeID, ss.Node(id))
+	}
+	if ss.Time(id).Before(time.UnixMilli(cfg.Epoch)) {
+		t.Errorf("decomposed time %v is before epoch", ss.Time(id))
+	}
+}
+
+func TestGenerateAndDecomposeHierarchical(t *testing.T) {
+	cfg := Config{
+		Epoch:          time.Now().Add(-time.Hour).UnixMilli(),
+		DataCenterBits: 5,
+		DataCenterID:   3,
+		WorkerBits:     5,
+		WorkerID:       7,
+		SequenceBits:   12,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	id, err := ss.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if ss.DataCenter(id) != cfg.DataCenterID {
+		t.Errorf("Expected dataCenterID %d, got %d", cfg.DataCenterID, ss.DataCenter(id))
+	}
+	if ss.Worker(id) != cfg.WorkerID {
+		t.Errorf("Expected workerID %d, got %d", cfg.WorkerID, ss.Worker(id))
+	}
+	if ss.Time(id).Before(time.UnixMilli(cfg.Epoch)) {
+		t.Errorf("decomposed time %v is before epoch", ss.Time(id))
+	}
+}
+
+func TestIDRoundTripEncodings(t *testing.T) {
+	id := ID(123456789012345)
+
+	for _, tc := range []struct {
+		name   string
+		encode func() string
+		decode func(string) (ID, error)
+	}{
+		{"Base2", id.Base2, ParseBase2},
+		{"Base32", id.Base32, ParseBase32},
+		{"Base58", id.Base58, ParseBase58},
+		{"Base62", id.Base62, ParseBase62},
+		{"Base64", id.Base64, ParseBase64},
+		{"String", id.String, ParseID},
+	} {
+		encoded := tc.encode()
+		decoded, err := tc.decode(encoded)
+		if err != nil {
+			t.Errorf("%s: decode failed: %v", tc.name, err)
+			continue
+		}
+		if decoded != id {
+			t.Errorf("%s: round-trip mismatch: got %d, want %d", tc.name, decoded, id)
+		}
+	}
+}
+
+func TestIDJSONMarshaling(t *testing.T) {
+	id := ID(123456789012345)
+
+	b, err := json.Marshal(id)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	want := `"123456789012345"`
+	if string(b) != want {
+		t.Errorf("Marshal got %s, want %s", b, want)
+	}
+
+	var decoded ID
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded != id {
+		t.Errorf("Unmarshal got %d, want %d", decoded, id)
+	}
+}
+
+func TestIDSQLValueAndScan(t *testing.T) {
+	id := ID(123456789012345)
+
+	v, err := id.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+
+	var scanned ID
+	if err := scanned.Scan(v); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if scanned != id {
+		t.Errorf("Scan got %d, want %d", scanned, id)
+	}
+}
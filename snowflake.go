@@ -7,29 +7,119 @@ import (
 	"time"
 )
 
+// TimePrecision controls the unit GenerateID uses when reading the clock.
+type TimePrecision int
+
+const (
+	// PrecisionMilli samples time.Now() at millisecond resolution. This is
+	// the default and matches the generator's original behavior.
+	PrecisionMilli TimePrecision = iota
+	// PrecisionSecond samples time.Now() at second resolution, trading
+	// timestamp resolution for roughly 136 years of headroom in 32 bits.
+	PrecisionSecond
+	// PrecisionMicro samples time.Now() at microsecond resolution, for
+	// low-latency systems that can burst more than 4096 IDs per millisecond.
+	PrecisionMicro
+)
+
 // SnowFlake is a generator for creating unique IDs based on the Snowflake algorithm.
 type SnowFlake struct {
-	mu            sync.Mutex // Ensures thread-safe ID generation.
-	epoch         int64      // Custom epoch to calculate timestamps.
-	nodeID        int64      // Unique identifier for the generator instance.
-	sequence      int64      // Tracks the sequence number within the same millisecond.
-	lastTimestamp int64      // Keeps the last used timestamp to handle clock adjustments.
-	maxSequence   int64      // Maximum value the sequence can take.
-	nodeShift     uint       // Bit shift for the node ID.
-	sequenceShift uint       // Bit shift for the sequence.
-	maxNodeID     int64      // Maximum valid node ID.
+	mu            sync.Mutex    // Ensures thread-safe ID generation.
+	epoch         int64         // Custom epoch to calculate timestamps, in the configured TimePrecision unit.
+	precision     TimePrecision // Unit used when sampling and encoding the current time.
+	nodeID        int64         // Unique identifier for the generator instance.
+	sequence      int64         // Tracks the sequence number within the same time unit.
+	lastTimestamp int64         // Keeps the last used timestamp to handle clock adjustments.
+	maxSequence   int64         // Maximum value the sequence can take.
+	nodeShift     uint          // Bit shift for the node ID.
+	sequenceShift uint          // Bit shift for the timestamp.
+	maxNodeID     int64         // Maximum valid node ID.
+
+	hierarchical    bool  // Whether the node segment is split into datacenter + worker.
+	dataCenterID    int64 // Unique datacenter identifier (hierarchical mode).
+	workerID        int64 // Unique worker identifier within a datacenter (hierarchical mode).
+	dataCenterShift uint  // Bit shift for the datacenter ID.
+	workerShift     uint  // Bit shift for the worker ID.
+	maxDataCenterID int64 // Maximum valid datacenter ID.
+	maxWorkerID     int64 // Maximum valid worker ID.
+
+	clockDriftPolicy  ClockDriftPolicy // How to react to the wall clock moving backward.
+	maxClockDriftWait time.Duration    // Bounds how long PolicyWait blocks for the clock to catch up.
+	maxFutureDrift    int64            // Bounds how many ticks PolicyExtendedSequence may borrow ahead of the clock.
+	driftWaits        int64            // Count of times PolicyWait waited out clock drift.
+	driftBorrows      int64            // Count of times PolicyExtendedSequence borrowed a future tick.
+
+	store             TimestampStore // Persists lastTimestamp so restarts can't reuse issued timestamps.
+	storeConfigured   bool           // Whether Config.Store was actually set, vs. defaulted to NopStore.
+	storeSaveEvery    int64          // Save after this many IDs; 0 disables the count-based trigger.
+	storeSaveInterval time.Duration  // Save after this much time has elapsed; 0 disables the time-based trigger.
+	sinceSave         int64          // IDs generated since the last Save.
+	lastSaveTime      time.Time      // Wall-clock time of the last Save.
+	saveCh            chan int64     // Feeds the single background save worker, keeping Saves ordered.
+	saveOnce          sync.Once      // Starts the background save worker at most once.
 }
 
 // Config holds the settings for initializing a SnowFlake generator.
 type Config struct {
-	Epoch        int64 // Start time for ID generation in milliseconds.
+	Epoch        int64 // Start time for ID generation, always given in milliseconds.
 	NodeID       int64 // Unique node ID for this generator.
 	NodeBits     int   // Number of bits allocated for the node ID.
 	SequenceBits int   // Number of bits allocated for the sequence.
+
+	// TimePrecision selects the clock resolution GenerateID samples. It
+	// defaults to PrecisionMilli, preserving the original behavior.
+	TimePrecision TimePrecision
+
+	// AutoNodeID, when true, tells NewSnowFlakeAuto to derive NodeID
+	// automatically instead of using the value above.
+	AutoNodeID bool
+
+	// DataCenterBits and WorkerBits, when either is non-zero, switch the generator
+	// into hierarchical mode: the node segment is split into a datacenter ID
+	// and a worker ID instead of the flat NodeID above. This lets the same
+	// WorkerID be reused across datacenters without collisions. NodeBits and
+	// NodeID are ignored in this mode.
+	DataCenterBits int
+	DataCenterID   int64
+	WorkerBits     int
+	WorkerID       int64
+
+	// ClockDriftPolicy controls how GenerateID reacts when the wall clock is
+	// behind the last issued timestamp. It defaults to PolicyError, which
+	// preserves the original hard-failure behavior.
+	ClockDriftPolicy ClockDriftPolicy
+
+	// MaxClockDriftWait bounds how long PolicyWait blocks for the clock to
+	// catch up before giving up and returning an error.
+	MaxClockDriftWait time.Duration
+
+	// MaxFutureDrift bounds, in the configured TimePrecision unit, how many
+	// ticks PolicyExtendedSequence may borrow ahead of the wall clock when
+	// the sequence overflows within the same tick.
+	MaxFutureDrift int64
+
+	// Store, when set, lets the generator persist the last issued timestamp
+	// so that a process restart can't reuse timestamps issued before it,
+	// even if the wall clock has moved backward in the meantime. It defaults
+	// to NopStore, which persists nothing.
+	Store TimestampStore
+
+	// StoreSaveEvery and StoreSaveInterval control how often GenerateID
+	// asynchronously calls Store.Save: after every StoreSaveEvery IDs, or
+	// after StoreSaveInterval has elapsed since the last save, whichever
+	// comes first. If both are left zero and Store is set, the generator
+	// saves after every ID. A save that comes due while an earlier one is
+	// still queued is skipped; a later save persists a newer timestamp.
+	StoreSaveEvery    int
+	StoreSaveInterval time.Duration
 }
 
 // NewSnowFlake creates and configures a new instance of the SnowFlake generator.
 func NewSnowFlake(cfg Config) (*SnowFlake, error) {
+	if cfg.DataCenterBits > 0 || cfg.WorkerBits > 0 {
+		return newHierarchicalSnowFlake(cfg)
+	}
+
 	if cfg.NodeBits+cfg.SequenceBits >= 63 {
 		return nil, errors.New("the sum of NodeBits and SequenceBits must be less than 63")
 	}
@@ -39,14 +129,65 @@ func NewSnowFlake(cfg Config) (*SnowFlake, error) {
 		return nil, fmt.Errorf("nodeID must be between 0 and %d", maxNodeID)
 	}
 
-	return &SnowFlake{
-		epoch:         cfg.Epoch,
-		nodeID:        cfg.NodeID,
-		maxSequence:   (1 << cfg.SequenceBits) - 1,
-		nodeShift:     uint(cfg.SequenceBits),
-		sequenceShift: uint(63 - cfg.NodeBits - cfg.SequenceBits),
-		maxNodeID:     int64(maxNodeID),
-	}, nil
+	ss := &SnowFlake{
+		epoch:             convertMillisToPrecision(cfg.Epoch, cfg.TimePrecision),
+		precision:         cfg.TimePrecision,
+		nodeID:            cfg.NodeID,
+		maxSequence:       (1 << cfg.SequenceBits) - 1,
+		nodeShift:         uint(cfg.SequenceBits),
+		sequenceShift:     uint(63 - cfg.NodeBits - cfg.SequenceBits),
+		maxNodeID:         int64(maxNodeID),
+		clockDriftPolicy:  cfg.ClockDriftPolicy,
+		maxClockDriftWait: cfg.MaxClockDriftWait,
+		maxFutureDrift:    cfg.MaxFutureDrift,
+	}
+
+	if err := ss.initTimestampStore(cfg); err != nil {
+		return nil, err
+	}
+
+	return ss, nil
+}
+
+// newHierarchicalSnowFlake configures a generator that splits the node
+// segment of the ID into a datacenter ID and a worker ID.
+func newHierarchicalSnowFlake(cfg Config) (*SnowFlake, error) {
+	if cfg.DataCenterBits+cfg.WorkerBits+cfg.SequenceBits >= 63 {
+		return nil, errors.New("the sum of DataCenterBits, WorkerBits and SequenceBits must be less than 63")
+	}
+
+	maxDataCenterID := (1 << cfg.DataCenterBits) - 1
+	if cfg.DataCenterID < 0 || cfg.DataCenterID > int64(maxDataCenterID) {
+		return nil, fmt.Errorf("dataCenterID must be between 0 and %d", maxDataCenterID)
+	}
+
+	maxWorkerID := (1 << cfg.WorkerBits) - 1
+	if cfg.WorkerID < 0 || cfg.WorkerID > int64(maxWorkerID) {
+		return nil, fmt.Errorf("workerID must be between 0 and %d", maxWorkerID)
+	}
+
+	ss := &SnowFlake{
+		epoch:             convertMillisToPrecision(cfg.Epoch, cfg.TimePrecision),
+		precision:         cfg.TimePrecision,
+		maxSequence:       (1 << cfg.SequenceBits) - 1,
+		sequenceShift:     uint(63 - cfg.DataCenterBits - cfg.WorkerBits - cfg.SequenceBits),
+		hierarchical:      true,
+		dataCenterID:      cfg.DataCenterID,
+		workerID:          cfg.WorkerID,
+		dataCenterShift:   uint(cfg.SequenceBits + cfg.WorkerBits),
+		workerShift:       uint(cfg.SequenceBits),
+		maxDataCenterID:   int64(maxDataCenterID),
+		maxWorkerID:       int64(maxWorkerID),
+		clockDriftPolicy:  cfg.ClockDriftPolicy,
+		maxClockDriftWait: cfg.MaxClockDriftWait,
+		maxFutureDrift:    cfg.MaxFutureDrift,
+	}
+
+	if err := ss.initTimestampStore(cfg); err != nil {
+		return nil, err
+	}
+
+	return ss, nil
 }
 
 // GenerateID produces a unique ID using the current timestamp, node ID, and sequence number.
@@ -54,34 +195,99 @@ func (ss *SnowFlake) GenerateID() (int64, error) {
 	ss.mu.Lock()
 	defer ss.mu.Unlock()
 
-	now := time.Now().UnixMilli()
+	return ss.nextIDLocked()
+}
+
+// Generate produces a unique ID the same way GenerateID does, but returns it
+// as the richer ID type, which carries its own encoding and serialization
+// helpers.
+func (ss *SnowFlake) Generate() (ID, error) {
+	id, err := ss.GenerateID()
+	if err != nil {
+		return 0, err
+	}
+	return ID(id), nil
+}
+
+// nextIDLocked generates a single ID, assuming ss.mu is already held by the caller.
+func (ss *SnowFlake) nextIDLocked() (int64, error) {
+	now := currentTime(ss.precision)
 	if now < ss.lastTimestamp {
-		return 0, fmt.Errorf("system clock moved backward: refusing to generate ID for %d milliseconds", ss.lastTimestamp-now)
+		switch ss.clockDriftPolicy {
+		case PolicyWait:
+			waited, err := ss.waitForClockLocked()
+			if err != nil {
+				return 0, err
+			}
+			now = waited
+			ss.driftWaits++
+		case PolicyExtendedSequence:
+			if ss.lastTimestamp-now > ss.maxFutureDrift {
+				return 0, fmt.Errorf("system clock moved backward: refusing to generate ID for %d time units, exceeding MaxFutureDrift of %d", ss.lastTimestamp-now, ss.maxFutureDrift)
+			}
+			// lastTimestamp is already borrowed ahead of the wall clock and
+			// still within bounds; keep issuing from it as if no time had
+			// passed, same as an ordinary same-tick call below.
+			now = ss.lastTimestamp
+		default:
+			return 0, fmt.Errorf("system clock moved backward: refusing to generate ID for %d time units", ss.lastTimestamp-now)
+		}
 	}
 
 	if now == ss.lastTimestamp {
 		ss.sequence = (ss.sequence + 1) & ss.maxSequence
 		if ss.sequence == 0 {
-			// Wait for the next millisecond when the sequence overflows.
-			for now <= ss.lastTimestamp {
-				now = time.Now().UnixMilli()
+			// The sequence overflowed within the same time unit. Normally we
+			// wait for the next tick; PolicyExtendedSequence instead borrows
+			// it, bounded by MaxFutureDrift, to avoid blocking.
+			if ss.clockDriftPolicy == PolicyExtendedSequence && ss.lastTimestamp+1-currentTime(ss.precision) <= ss.maxFutureDrift {
+				now = ss.lastTimestamp + 1
+				ss.driftBorrows++
+			} else {
+				for now <= ss.lastTimestamp {
+					now = currentTime(ss.precision)
+				}
 			}
 		}
 	} else {
-		ss.sequence = 0 // Reset sequence for a new millisecond.
+		ss.sequence = 0 // Reset sequence for a new time unit.
 	}
 
 	ss.lastTimestamp = now
+	ss.maybeSaveTimestampLocked()
 
-	// Construct the ID by combining timestamp, node ID, and sequence.
-	id := ((now - ss.epoch) << ss.sequenceShift) |
-		(ss.nodeID << ss.nodeShift) |
-		ss.sequence
+	// Construct the ID by combining timestamp, node segment, and sequence.
+	id := (now - ss.epoch) << ss.sequenceShift
+	if ss.hierarchical {
+		id |= (ss.dataCenterID << ss.dataCenterShift) | (ss.workerID << ss.workerShift)
+	} else {
+		id |= ss.nodeID << ss.nodeShift
+	}
+	id |= ss.sequence
 
 	return id, nil
 }
 
+// waitForClockLocked blocks, assuming ss.mu is already held, until the clock
+// catches up to ss.lastTimestamp or MaxClockDriftWait elapses, whichever
+// comes first.
+func (ss *SnowFlake) waitForClockLocked() (int64, error) {
+	deadline := time.Now().Add(ss.maxClockDriftWait)
+	for {
+		now := currentTime(ss.precision)
+		if now >= ss.lastTimestamp {
+			return now, nil
+		}
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("system clock moved backward: exceeded MaxClockDriftWait of %s waiting for the clock to catch up", ss.maxClockDriftWait)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
 // DecomposeID breaks down a generated ID into its timestamp, node ID, and sequence components.
+// It is only valid for generators configured in flat NodeID mode; use
+// DecomposeHierarchical for generators configured with DataCenterBits/WorkerBits.
 func (ss *SnowFlake) DecomposeID(id int64) (timestamp, nodeID, sequence int64) {
 	timestamp = (id >> ss.sequenceShift) + ss.epoch
 	nodeID = (id >> ss.nodeShift) & ((1 << uint(63-ss.sequenceShift-ss.nodeShift)) - 1)
@@ -89,6 +295,91 @@ func (ss *SnowFlake) DecomposeID(id int64) (timestamp, nodeID, sequence int64) {
 	return
 }
 
+// DecomposeTime extracts the timestamp embedded in a generated ID as a time.Time,
+// correctly inverting whichever TimePrecision the generator was configured with.
+func (ss *SnowFlake) DecomposeTime(id int64) time.Time {
+	raw := (id >> ss.sequenceShift) + ss.epoch
+	switch ss.precision {
+	case PrecisionSecond:
+		return time.Unix(raw, 0)
+	case PrecisionMicro:
+		return time.UnixMicro(raw)
+	default:
+		return time.UnixMilli(raw)
+	}
+}
+
+// currentTime samples the wall clock in the unit matching the given precision.
+func currentTime(precision TimePrecision) int64 {
+	switch precision {
+	case PrecisionSecond:
+		return time.Now().Unix()
+	case PrecisionMicro:
+		return time.Now().UnixMicro()
+	default:
+		return time.Now().UnixMilli()
+	}
+}
+
+// convertMillisToPrecision converts a millisecond epoch value, as accepted by
+// Config.Epoch, into the unit used internally for the given precision.
+func convertMillisToPrecision(epochMillis int64, precision TimePrecision) int64 {
+	switch precision {
+	case PrecisionSecond:
+		return epochMillis / 1000
+	case PrecisionMicro:
+		return epochMillis * 1000
+	default:
+		return epochMillis
+	}
+}
+
+// DecomposeHierarchical breaks down a generated ID into its timestamp, datacenter ID,
+// worker ID, and sequence components. It is only valid for generators configured with
+// DataCenterBits/WorkerBits; use DecomposeID for the flat NodeID mode.
+func (ss *SnowFlake) DecomposeHierarchical(id int64) (timestamp, dataCenterID, workerID, sequence int64) {
+	timestamp = (id >> ss.sequenceShift) + ss.epoch
+	dataCenterID = (id >> ss.dataCenterShift) & ss.maxDataCenterID
+	workerID = (id >> ss.workerShift) & ss.maxWorkerID
+	sequence = id & ss.maxSequence
+	return
+}
+
+// Time returns the timestamp embedded in id, decoded using this generator's
+// own epoch and TimePrecision. Unlike a bare ID, ss knows how it built id, so
+// this is safe to call even when multiple differently configured generators
+// are in use concurrently.
+func (ss *SnowFlake) Time(id ID) time.Time {
+	return ss.DecomposeTime(int64(id))
+}
+
+// Node returns the node ID embedded in id. It is only meaningful for IDs
+// produced by a generator configured in flat NodeID mode; use DataCenter and
+// Worker for hierarchical mode.
+func (ss *SnowFlake) Node(id ID) int64 {
+	_, nodeID, _ := ss.DecomposeID(int64(id))
+	return nodeID
+}
+
+// Sequence returns the sequence number embedded in id.
+func (ss *SnowFlake) Sequence(id ID) int64 {
+	return int64(id) & ss.maxSequence
+}
+
+// DataCenter returns the datacenter ID embedded in id. It is only meaningful
+// for IDs produced by a generator configured with DataCenterBits/WorkerBits.
+func (ss *SnowFlake) DataCenter(id ID) int64 {
+	_, dataCenterID, _, _ := ss.DecomposeHierarchical(int64(id))
+	return dataCenterID
+}
+
+// Worker returns the worker ID embedded in id. It is only meaningful for IDs
+// produced by a generator configured with DataCenterBits/WorkerBits.
+func (ss *SnowFlake) Worker(id ID) int64 {
+	_, _, workerID, _ := ss.DecomposeHierarchical(int64(id))
+	return workerID
+}
+
 // GenerateCustomID creates a unique ID and returns it as a base62-encoded string with a specific length.
 func (ss *SnowFlake) GenerateCustomID(length int) (string, error) {
 	if length <= 0 {
@@ -112,16 +403,19 @@ func (ss *SnowFlake) GenerateCustomID(length int) (string, error) {
 	return idStr, nil
 }
 
+// base62Charset is the digit alphabet used by encodeToBase62 and by ID's
+// Base62/ParseBase62 methods.
+const base62Charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
 // encodeToBase62 converts an integer ID into a base62-encoded string.
 func encodeToBase62(id int64) string {
-	const charset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 	if id == 0 {
 		return "0"
 	}
 
 	result := make([]byte, 0)
 	for id > 0 {
-		result = append([]byte{charset[id%62]}, result...)
+		result = append([]byte{base62Charset[id%62]}, result...)
 		id /= 62
 	}
 	return string(result)
@@ -81,6 +81,72 @@ func TestGenerateCustomID(t *testing.T) {
 	}
 }
 
+func TestGenerateIDHierarchical(t *testing.T) {
+	cfg := Config{
+		Epoch:          time.Now().Add(-time.Hour).UnixMilli(),
+		DataCenterBits: 5,
+		DataCenterID:   3,
+		WorkerBits:     5,
+		WorkerID:       7,
+		SequenceBits:   12,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	id, err := ss.GenerateID()
+	if err != nil {
+		t.Fatalf("GenerateID failed: %v", err)
+	}
+
+	_, dataCenterID, workerID, _ := ss.DecomposeHierarchical(id)
+	if dataCenterID != cfg.DataCenterID {
+		t.Errorf("Expected dataCenterID %d, got %d", cfg.DataCenterID, dataCenterID)
+	}
+	if workerID != cfg.WorkerID {
+		t.Errorf("Expected workerID %d, got %d", cfg.WorkerID, workerID)
+	}
+}
+
+func TestNewSnowFlakeHierarchicalInvalidBits(t *testing.T) {
+	cfg := Config{
+		DataCenterBits: 21,
+		WorkerBits:     21,
+		SequenceBits:   21,
+	}
+
+	if _, err := NewSnowFlake(cfg); err == nil {
+		t.Error("NewSnowFlake should fail when DataCenterBits+WorkerBits+SequenceBits >= 63")
+	}
+}
+
+func TestGenerateIDSecondPrecision(t *testing.T) {
+	cfg := Config{
+		Epoch:         time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:        1,
+		NodeBits:      10,
+		SequenceBits:  12,
+		TimePrecision: PrecisionSecond,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	id, err := ss.GenerateID()
+	if err != nil {
+		t.Fatalf("GenerateID failed: %v", err)
+	}
+
+	got := ss.DecomposeTime(id)
+	if diff := time.Since(got); diff < 0 || diff > 2*time.Second {
+		t.Errorf("DecomposeTime returned an implausible timestamp: %v (diff %v)", got, diff)
+	}
+}
+
 func TestValidateNodeID(t *testing.T) {
 	cfg := Config{
 		Epoch:        time.Now().UnixMilli(),
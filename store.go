@@ -0,0 +1,154 @@
+package snowflake
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimestampStore lets a SnowFlake generator persist the last timestamp it
+// issued an ID for, so that a process restart can detect and refuse to
+// reuse timestamps issued before the crash, even if the wall clock itself
+// has moved backward in the meantime.
+type TimestampStore interface {
+	// Load returns the last persisted timestamp, or 0 if none has been saved yet.
+	Load() (int64, error)
+	// Save persists ts as the last issued timestamp.
+	Save(ts int64) error
+}
+
+// NopStore is a TimestampStore that persists nothing. It is the default
+// when Config.Store is left unset.
+type NopStore struct{}
+
+// Load always returns 0, nil.
+func (NopStore) Load() (int64, error) { return 0, nil }
+
+// Save is a no-op.
+func (NopStore) Save(ts int64) error { return nil }
+
+// FileStore persists the last timestamp to a file on disk, using an atomic
+// write-and-rename so that a crash mid-write can't corrupt the stored value.
+type FileStore struct {
+	Path string
+}
+
+// NewFileStore returns a FileStore that persists to the file at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{Path: path}
+}
+
+// Load reads the last persisted timestamp from disk, returning 0 if the file does not exist yet.
+func (s *FileStore) Load() (int64, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	ts, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("snowflake: FileStore: invalid timestamp in %s: %w", s.Path, err)
+	}
+	return ts, nil
+}
+
+// Save atomically writes ts to disk via a temp file followed by a rename.
+func (s *FileStore) Save(ts int64) error {
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatInt(ts, 10)), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.Path)
+}
+
+// initTimestampStore wires up cfg.Store (defaulting to NopStore, in which
+// case GenerateID never schedules a save at all) and seeds lastTimestamp
+// from it, refusing to let the generator start earlier than the last
+// timestamp it previously persisted.
+func (ss *SnowFlake) initTimestampStore(cfg Config) error {
+	store := cfg.Store
+	ss.storeConfigured = store != nil
+	if store == nil {
+		store = NopStore{}
+	}
+	ss.store = store
+
+	if ss.storeConfigured {
+		ss.storeSaveEvery = int64(cfg.StoreSaveEvery)
+		ss.storeSaveInterval = cfg.StoreSaveInterval
+		if ss.storeSaveEvery <= 0 && ss.storeSaveInterval <= 0 {
+			ss.storeSaveEvery = 1
+		}
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("snowflake: failed to load persisted timestamp: %w", err)
+	}
+
+	now := currentTime(ss.precision)
+	if loaded > now {
+		ss.lastTimestamp = loaded
+	} else {
+		ss.lastTimestamp = now
+	}
+	ss.lastSaveTime = time.Now()
+
+	return nil
+}
+
+// maybeSaveTimestampLocked hands ss.lastTimestamp to the background save
+// worker once the configured count or time threshold is reached. The caller
+// must already hold ss.mu. When Config.Store was never set, this is a no-op
+// so callers who opted into nothing pay no overhead on the ID-generation
+// hot path.
+func (ss *SnowFlake) maybeSaveTimestampLocked() {
+	if !ss.storeConfigured {
+		return
+	}
+
+	ss.sinceSave++
+
+	due := ss.storeSaveEvery > 0 && ss.sinceSave >= ss.storeSaveEvery
+	if !due && ss.storeSaveInterval > 0 && time.Since(ss.lastSaveTime) >= ss.storeSaveInterval {
+		due = true
+	}
+	if !due {
+		return
+	}
+
+	ss.sinceSave = 0
+	ss.lastSaveTime = time.Now()
+
+	ss.saveOnce.Do(ss.startSaveWorker)
+
+	// ts values handed to this channel are produced while ss.mu is held, so
+	// they arrive here in non-decreasing order. The worker drains them one
+	// at a time, so Save calls are always serialized and never reordered.
+	// A full buffer means a save is already queued; dropping this one just
+	// means the next threshold hit will persist a more recent timestamp.
+	select {
+	case ss.saveCh <- ss.lastTimestamp:
+	default:
+	}
+}
+
+// startSaveWorker launches the single goroutine responsible for calling
+// Store.Save, so saves are strictly ordered instead of racing as
+// independent goroutines. Save errors are best-effort and intentionally
+// dropped: a missed persist only widens the window the next restart has to
+// protect against, it doesn't corrupt already-issued IDs.
+func (ss *SnowFlake) startSaveWorker() {
+	ss.saveCh = make(chan int64, 1)
+	store := ss.store
+	go func() {
+		for ts := range ss.saveCh {
+			_ = store.Save(ts)
+		}
+	}()
+}
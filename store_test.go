@@ -0,0 +1,153 @@
+package snowflake
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileStoreSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snowflake-ts")
+	store := NewFileStore(path)
+
+	if ts, err := store.Load(); err != nil || ts != 0 {
+		t.Fatalf("Load on a missing file should return (0, nil), got (%d, %v)", ts, err)
+	}
+
+	if err := store.Save(123456789); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	ts, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if ts != 123456789 {
+		t.Errorf("Expected loaded timestamp 123456789, got %d", ts)
+	}
+}
+
+func TestNewSnowFlakeSeedsLastTimestampFromStore(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "snowflake-ts"))
+
+	future := currentTime(PrecisionMilli) + 60_000
+	if err := store.Save(future); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	cfg := Config{
+		Epoch:          time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:         1,
+		NodeBits:       10,
+		SequenceBits:   12,
+		Store:          store,
+		StoreSaveEvery: 1,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	if ss.lastTimestamp != future {
+		t.Errorf("Expected lastTimestamp seeded to %d, got %d", future, ss.lastTimestamp)
+	}
+
+	// The persisted timestamp is in the future relative to the wall clock,
+	// so without clock-drift tolerance the generator must refuse to issue IDs.
+	if _, err := ss.GenerateID(); err == nil {
+		t.Error("GenerateID should refuse to issue an ID earlier than the persisted timestamp")
+	}
+}
+
+// orderTrackingStore records every Save call, with an artificial delay that
+// favors reordering if saves were ever dispatched as independent racing
+// goroutines instead of through the single serialized worker.
+type orderTrackingStore struct {
+	mu   sync.Mutex
+	got  []int64
+	call int
+}
+
+func (s *orderTrackingStore) Load() (int64, error) { return 0, nil }
+
+func (s *orderTrackingStore) Save(ts int64) error {
+	s.mu.Lock()
+	s.call++
+	delay := s.call % 3 // vary latency across calls to stress ordering
+	s.mu.Unlock()
+
+	time.Sleep(time.Duration(delay) * time.Millisecond)
+
+	s.mu.Lock()
+	s.got = append(s.got, ts)
+	s.mu.Unlock()
+	return nil
+}
+
+func TestSavesAreSerializedInOrder(t *testing.T) {
+	store := &orderTrackingStore{}
+	cfg := Config{
+		Epoch:          time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:         1,
+		NodeBits:       10,
+		SequenceBits:   12,
+		Store:          store,
+		StoreSaveEvery: 1,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	const n = 30
+	if _, err := ss.GenerateIDs(n); err != nil {
+		t.Fatalf("GenerateIDs failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		store.mu.Lock()
+		done := store.call >= n
+		store.mu.Unlock()
+		if done || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for i := 1; i < len(store.got); i++ {
+		if store.got[i] < store.got[i-1] {
+			t.Fatalf("Save calls arrived out of order: %v", store.got)
+		}
+	}
+}
+
+func TestNoSaveSchedulingWithoutStore(t *testing.T) {
+	cfg := Config{
+		Epoch:        time.Now().Add(-time.Hour).UnixMilli(),
+		NodeID:       1,
+		NodeBits:     10,
+		SequenceBits: 12,
+	}
+
+	ss, err := NewSnowFlake(cfg)
+	if err != nil {
+		t.Fatalf("Failed to initialize SnowFlake: %v", err)
+	}
+
+	if _, err := ss.GenerateIDs(50); err != nil {
+		t.Fatalf("GenerateIDs failed: %v", err)
+	}
+
+	if ss.storeConfigured {
+		t.Error("storeConfigured should be false when Config.Store is left unset")
+	}
+	if ss.sinceSave != 0 {
+		t.Errorf("maybeSaveTimestampLocked should short-circuit without a configured Store, got sinceSave=%d", ss.sinceSave)
+	}
+}